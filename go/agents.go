@@ -65,8 +65,9 @@ func (a *AgentAPI) Get(ctx context.Context, agentID string) (*Agent, error) {
 	return &agent, nil
 }
 
-// Register creates a new agent.
-func (a *AgentAPI) Register(ctx context.Context, opts *RegisterAgentOptions) (*Agent, error) {
+// Register creates a new agent. Pass WithIdempotencyKey so a retried
+// request on a 5xx or timeout doesn't register the agent twice.
+func (a *AgentAPI) Register(ctx context.Context, opts *RegisterAgentOptions, reqOpts ...RequestOption) (*Agent, error) {
 	if opts.Type == "" {
 		opts.Type = "ai"
 	}
@@ -74,7 +75,7 @@ func (a *AgentAPI) Register(ctx context.Context, opts *RegisterAgentOptions) (*A
 		opts.Level = 4
 	}
 
-	resp, err := a.client.Post(ctx, "/agents", opts)
+	resp, err := a.client.Post(ctx, "/agents", opts, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
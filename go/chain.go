@@ -0,0 +1,122 @@
+package blackroad
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// canonicalEntry mirrors MemoryEntry but fixes the field order and formats
+// used when computing an entry's hash, so the same bytes are produced
+// regardless of which Go struct tags or map iteration order produced the
+// entry.
+type canonicalEntry struct {
+	Timestamp string                 `json:"timestamp"`
+	Action    string                 `json:"action"`
+	Entity    string                 `json:"entity"`
+	Details   string                 `json:"details"`
+	Agent     string                 `json:"agent"`
+	Tags      []string               `json:"tags"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	PrevHash  string                 `json:"prev_hash"`
+}
+
+// CanonicalizeEntry serializes entry as canonical JSON: sorted tags,
+// RFC3339Nano timestamps, and map keys sorted by encoding/json's default
+// map-marshaling order. The result is suitable for hashing and is what the
+// server is expected to have hashed to produce entry.Hash.
+func CanonicalizeEntry(entry MemoryEntry) ([]byte, error) {
+	tags := append([]string{}, entry.Tags...)
+	sort.Strings(tags)
+
+	ce := canonicalEntry{
+		Timestamp: entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		Action:    entry.Action,
+		Entity:    entry.Entity,
+		Details:   entry.Details,
+		Agent:     entry.Agent,
+		Tags:      tags,
+		Metadata:  entry.Metadata,
+		PrevHash:  entry.PrevHash,
+	}
+	return json.Marshal(&ce)
+}
+
+func hashEntry(entry MemoryEntry) (string, error) {
+	canon, err := CanonicalizeEntry(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canon)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChainLocal recomputes and checks the hash chain for entries
+// entirely client-side, independent of the server's /memory/verify
+// endpoint. Entries are checked in timestamp order; the earliest entry is
+// treated as the chain's genesis and may have an empty PrevHash. On the
+// first mismatch it returns a *ChainBreakError naming the broken entry,
+// its index, and the expected vs. actual value for the offending field.
+func (m *MemoryAPI) VerifyChainLocal(ctx context.Context, entries []MemoryEntry) (*VerifyChainResult, error) {
+	sorted := append([]MemoryEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	prevHash := ""
+	for i, entry := range sorted {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if i > 0 && entry.PrevHash != prevHash {
+			return nil, NewChainBreakError(i, "prev_hash", prevHash, entry.PrevHash)
+		}
+
+		computed, err := hashEntry(entry)
+		if err != nil {
+			return nil, NewConnectionError("failed to canonicalize entry", err)
+		}
+		if computed != entry.Hash {
+			return nil, NewChainBreakError(i, "hash", computed, entry.Hash)
+		}
+
+		prevHash = entry.Hash
+	}
+
+	return &VerifyChainResult{Valid: true, Checked: len(sorted)}, nil
+}
+
+// VerifyRange pages through /memory entries between since and until and
+// verifies the resulting hash chain with VerifyChainLocal. It lets callers
+// audit integrity over a time window without first materializing every
+// entry themselves.
+func (m *MemoryAPI) VerifyRange(ctx context.Context, since, until time.Time) (*VerifyChainResult, error) {
+	const pageSize = 200
+
+	var all []MemoryEntry
+	offset := 0
+	for {
+		page, err := m.Query(ctx, &MemoryQueryOptions{
+			Since:  &since,
+			Until:  &until,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			break
+		}
+		offset += pageSize
+	}
+
+	return m.VerifyChainLocal(ctx, all)
+}
@@ -0,0 +1,79 @@
+package blackroad
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestCanonicalizeEntryDeterministic checks that hashing is independent of
+// Tags input order and stable across repeated calls on the same entry, since
+// VerifyChainLocal relies on both properties to detect real chain breaks
+// instead of spurious ones.
+func TestCanonicalizeEntryDeterministic(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := MemoryEntry{
+		Timestamp: ts,
+		Action:    "create",
+		Entity:    "task-1",
+		Tags:      []string{"b", "a", "c"},
+		Metadata:  map[string]interface{}{"z": 1, "a": 2},
+	}
+	reordered := base
+	reordered.Tags = []string{"c", "a", "b"}
+
+	h1, err := hashEntry(base)
+	if err != nil {
+		t.Fatalf("hashEntry(base): %v", err)
+	}
+	h2, err := hashEntry(reordered)
+	if err != nil {
+		t.Fatalf("hashEntry(reordered): %v", err)
+	}
+	if h1 != h2 {
+		t.Errorf("hash depends on Tags input order: %q != %q", h1, h2)
+	}
+
+	h3, err := hashEntry(base)
+	if err != nil {
+		t.Fatalf("hashEntry(base) again: %v", err)
+	}
+	if h1 != h3 {
+		t.Errorf("hashEntry is not stable across repeated calls: %q != %q", h1, h3)
+	}
+}
+
+// TestVerifyChainLocalStableForTiedTimestamps checks that entries sharing a
+// Timestamp aren't reordered relative to each other by VerifyChainLocal,
+// which would otherwise spuriously trip PrevHash mismatches across repeated
+// calls on the same input.
+func TestVerifyChainLocalStableForTiedTimestamps(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := MemoryEntry{Timestamp: ts, Action: "create", Entity: "task-1"}
+	firstHash, err := hashEntry(first)
+	if err != nil {
+		t.Fatalf("hashEntry(first): %v", err)
+	}
+	first.Hash = firstHash
+
+	second := MemoryEntry{Timestamp: ts, Action: "create", Entity: "task-2", PrevHash: firstHash}
+	secondHash, err := hashEntry(second)
+	if err != nil {
+		t.Fatalf("hashEntry(second): %v", err)
+	}
+	second.Hash = secondHash
+
+	m := &MemoryAPI{}
+	ctx := context.Background()
+	entries := []MemoryEntry{first, second}
+	for i := 0; i < 5; i++ {
+		result, err := m.VerifyChainLocal(ctx, append([]MemoryEntry{}, entries...))
+		if err != nil {
+			t.Fatalf("iteration %d: VerifyChainLocal: %v", i, err)
+		}
+		if !result.Valid || result.Checked != 2 {
+			t.Fatalf("iteration %d: got %+v, want valid chain of 2", i, result)
+		}
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -36,15 +35,33 @@ type ClientConfig struct {
 
 	// HTTPClient allows providing a custom HTTP client.
 	HTTPClient *http.Client
+
+	// RetryPolicy controls whether and how failed requests are retried.
+	// Defaults to DefaultRetryPolicy(). Pass NoRetry{} to disable
+	// policy-driven retries; a failure to read a response body is still
+	// retried, up to MaxRetries attempts in all.
+	RetryPolicy RetryPolicy
+
+	// Middlewares wrap every request attempt, in order, around the
+	// underlying HTTP round trip. Useful for cross-cutting concerns like
+	// logging or metrics that every API method should pick up automatically.
+	Middlewares []Middleware
 }
 
 // Client is the BlackRoad API client.
 type Client struct {
-	apiKey     string
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
-	httpClient *http.Client
+	apiKey      string
+	baseURL     string
+	timeout     time.Duration
+	maxRetries  int
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	middlewares []Middleware
+
+	// streamClient is used for long-lived connections (SSE subscriptions,
+	// log tailing) where httpClient's request-wide Timeout would otherwise
+	// tear down the connection after Timeout has elapsed.
+	streamClient *http.Client
 
 	// API modules
 	Agents *AgentAPI
@@ -103,12 +120,22 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		}
 	}
 
+	retryPolicy := config.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	c := &Client{
-		apiKey:     apiKey,
-		baseURL:    baseURL,
-		timeout:    timeout,
-		maxRetries: maxRetries,
-		httpClient: httpClient,
+		apiKey:      apiKey,
+		baseURL:     baseURL,
+		timeout:     timeout,
+		maxRetries:  maxRetries,
+		httpClient:  httpClient,
+		retryPolicy: retryPolicy,
+		middlewares: config.Middlewares,
+		streamClient: &http.Client{
+			Transport: httpClient.Transport,
+		},
 	}
 
 	// Initialize API modules
@@ -120,23 +147,59 @@ func NewClient(config *ClientConfig) (*Client, error) {
 }
 
 // request makes an HTTP request to the API.
-func (c *Client) request(ctx context.Context, method, endpoint string, body interface{}, params url.Values) ([]byte, error) {
+func (c *Client) request(ctx context.Context, method, endpoint string, body interface{}, params url.Values, opts ...RequestOption) ([]byte, error) {
+	ro := newRequestOptions(opts)
+
 	fullURL := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(endpoint, "/"))
 	if len(params) > 0 {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, params.Encode())
 	}
 
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, NewConnectionError("failed to marshal request body", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
+	if ro.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ro.timeout)
+		defer cancel()
+	}
+
+	idempotencyKey := ro.idempotencyKey
+	if idempotencyKey == "" && (method == http.MethodPost || method == http.MethodPut) {
+		idempotencyKey = generateIdempotencyKey()
+	}
+	// Only replay the same idempotency key across retries of a write if one
+	// was actually supplied or auto-generated for this request; GET/DELETE
+	// never send one.
+	canRetryWrite := method == http.MethodPost || method == http.MethodPut
+
+	policy := c.retryPolicy
+	if ro.retryPolicy != nil {
+		policy = ro.retryPolicy
+	}
+
+	roundTrip := chainMiddleware(c.httpClient.Do, c.middlewares)
+
+	start := time.Now()
 	var lastErr error
 	for attempt := 0; attempt < c.maxRetries; attempt++ {
+		if ro.rateLimit != nil {
+			if err := ro.rateLimit.Wait(ctx); err != nil {
+				return nil, NewConnectionError("rate limit wait failed", err)
+			}
+		}
+
+		var bodyReader io.Reader
+		if jsonBody != nil {
+			bodyReader = bytes.NewReader(jsonBody)
+		}
+
 		req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 		if err != nil {
 			return nil, NewConnectionError("failed to create request", err)
@@ -145,16 +208,34 @@ func (c *Client) request(ctx context.Context, method, endpoint string, body inte
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("User-Agent", "blackroad-go/1.0.0")
+		if canRetryWrite {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		if ro.deadlineFor > 0 {
+			req.Header.Set("X-Client-Deadline", time.Now().Add(ro.deadlineFor).UTC().Format(time.RFC3339Nano))
+		}
+		for k, v := range ro.headers {
+			req.Header.Set(k, v)
+		}
 
-		resp, err := c.httpClient.Do(req)
+		resp, err := roundTrip(req)
 		if err != nil {
 			lastErr = NewConnectionError("request failed", err)
-			time.Sleep(time.Duration(1<<attempt) * time.Second)
+			if !isIdempotentMethod(method) && !canRetryWrite {
+				return nil, lastErr
+			}
+			retry, delay := policy.ShouldRetry(attempt, start, nil, err)
+			if !retry || attempt >= c.maxRetries-1 {
+				return nil, lastErr
+			}
+			if !sleepCtx(ctx, delay) {
+				return nil, ctx.Err()
+			}
 			continue
 		}
-		defer resp.Body.Close()
 
 		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			lastErr = NewConnectionError("failed to read response", err)
 			continue
@@ -171,25 +252,32 @@ func (c *Client) request(ctx context.Context, method, endpoint string, body inte
 			return nil, NewNotFoundError(endpoint)
 		case 422:
 			return nil, NewValidationError(string(respBody))
-		case 429:
-			retryAfter := 1
-			if ra := resp.Header.Get("Retry-After"); ra != "" {
-				if parsed, err := strconv.Atoi(ra); err == nil {
-					retryAfter = parsed
+		}
+
+		retryable := isIdempotentMethod(method) || canRetryWrite
+		if retryable && attempt < c.maxRetries-1 {
+			if retry, delay := policy.ShouldRetry(attempt, start, resp, nil); retry {
+				if !sleepCtx(ctx, delay) {
+					return nil, ctx.Err()
 				}
-			}
-			if attempt < c.maxRetries-1 {
-				time.Sleep(time.Duration(retryAfter) * time.Second)
 				continue
 			}
-			return nil, NewRateLimitError(retryAfter)
-		default:
-			lastErr = &Error{
-				Message:    fmt.Sprintf("API error: %s", string(respBody)),
-				Code:       "API_ERROR",
-				StatusCode: resp.StatusCode,
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			retryAfter := 1
+			if d, ok := retryAfterDelay(resp); ok {
+				retryAfter = int(d.Seconds())
 			}
+			return nil, NewRateLimitError(retryAfter)
+		}
+
+		lastErr = &Error{
+			Message:    fmt.Sprintf("API error: %s", string(respBody)),
+			Code:       "API_ERROR",
+			StatusCode: resp.StatusCode,
 		}
+		return nil, lastErr
 	}
 
 	if lastErr != nil {
@@ -198,24 +286,90 @@ func (c *Client) request(ctx context.Context, method, endpoint string, body inte
 	return nil, &Error{Message: "max retries exceeded"}
 }
 
+// stream issues a GET request expecting a text/event-stream response and
+// returns the raw *http.Response for incremental reading. It uses a client
+// with no request-wide timeout since the connection is meant to stay open
+// indefinitely; the caller must close the response body. lastEventID, if
+// non-empty, is sent as the Last-Event-ID header so the server can resume
+// from where a previous connection left off. Like request, it honors
+// WithRateLimit, WithDeadlineHeader, WithHeader, and the client's middleware
+// chain, so streaming calls (Subscribe, Logs, StreamLogs) pick up those
+// per-call overrides as Get/Post/Put/Delete do. WithRequestTimeout,
+// WithRetryPolicy, and WithIdempotencyKey have no effect here.
+func (c *Client) stream(ctx context.Context, endpoint string, params url.Values, lastEventID string, opts ...RequestOption) (*http.Response, error) {
+	ro := newRequestOptions(opts)
+
+	if ro.rateLimit != nil {
+		if err := ro.rateLimit.Wait(ctx); err != nil {
+			return nil, NewConnectionError("rate limit wait failed", err)
+		}
+	}
+
+	fullURL := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(endpoint, "/"))
+	if len(params) > 0 {
+		fullURL = fmt.Sprintf("%s?%s", fullURL, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, NewConnectionError("failed to create request", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", "blackroad-go/1.0.0")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	if ro.deadlineFor > 0 {
+		req.Header.Set("X-Client-Deadline", time.Now().Add(ro.deadlineFor).UTC().Format(time.RFC3339Nano))
+	}
+	for k, v := range ro.headers {
+		req.Header.Set(k, v)
+	}
+
+	roundTrip := chainMiddleware(c.streamClient.Do, c.middlewares)
+	resp, err := roundTrip(req)
+	if err != nil {
+		return nil, NewConnectionError("stream request failed", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		switch resp.StatusCode {
+		case 401:
+			return nil, NewAuthenticationError("invalid API key")
+		case 404:
+			return nil, NewNotFoundError(endpoint)
+		default:
+			return nil, &Error{
+				Message:    fmt.Sprintf("API error: %s", string(respBody)),
+				Code:       "API_ERROR",
+				StatusCode: resp.StatusCode,
+			}
+		}
+	}
+	return resp, nil
+}
+
 // Get makes a GET request.
-func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
-	return c.request(ctx, http.MethodGet, endpoint, nil, params)
+func (c *Client) Get(ctx context.Context, endpoint string, params url.Values, opts ...RequestOption) ([]byte, error) {
+	return c.request(ctx, http.MethodGet, endpoint, nil, params, opts...)
 }
 
-// Post makes a POST request.
-func (c *Client) Post(ctx context.Context, endpoint string, body interface{}) ([]byte, error) {
-	return c.request(ctx, http.MethodPost, endpoint, body, nil)
+// Post makes a POST request. Pass WithIdempotencyKey to make retries safe
+// against duplicate server-side effects.
+func (c *Client) Post(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) ([]byte, error) {
+	return c.request(ctx, http.MethodPost, endpoint, body, nil, opts...)
 }
 
 // Put makes a PUT request.
-func (c *Client) Put(ctx context.Context, endpoint string, body interface{}) ([]byte, error) {
-	return c.request(ctx, http.MethodPut, endpoint, body, nil)
+func (c *Client) Put(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) ([]byte, error) {
+	return c.request(ctx, http.MethodPut, endpoint, body, nil, opts...)
 }
 
 // Delete makes a DELETE request.
-func (c *Client) Delete(ctx context.Context, endpoint string) ([]byte, error) {
-	return c.request(ctx, http.MethodDelete, endpoint, nil, nil)
+func (c *Client) Delete(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+	return c.request(ctx, http.MethodDelete, endpoint, nil, nil, opts...)
 }
 
 // Health checks the API health status.
@@ -0,0 +1,72 @@
+package blackroad
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer implements net.Conn-style deadline semantics for a single
+// logical stream: callers block on <-channel() to learn when the current
+// deadline has elapsed, and setDeadline can push the deadline out or pull
+// it in at any time without anyone tearing down the underlying connection.
+type deadlineTimer struct {
+	mu     sync.Mutex
+	cancel chan struct{}
+	timer  *time.Timer
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{cancel: make(chan struct{})}
+}
+
+// channel returns the current cancellation channel. It is closed once the
+// current deadline elapses, or at once when a deadline in the past is set.
+func (d *deadlineTimer) channel() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancel
+}
+
+// setDeadline arms the deadline at t, or disarms it for a zero time.Time.
+// If the prior timer could not be stopped (it already fired, and its
+// callback may still be about to close the old channel) or the channel is
+// already closed, the cancel channel is swapped for a fresh one so waiters
+// on the new deadline aren't woken early. Otherwise the same channel is
+// kept, and its waiters now wake at the new deadline.
+func (d *deadlineTimer) setDeadline(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		if !d.timer.Stop() {
+			d.cancel = make(chan struct{})
+		}
+		d.timer = nil
+	}
+	select {
+	case <-d.cancel:
+		d.cancel = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	if !t.After(now) {
+		close(d.cancel)
+		return
+	}
+
+	cancel := d.cancel
+	d.timer = time.AfterFunc(t.Sub(now), func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		select {
+		case <-cancel:
+		default:
+			close(cancel)
+		}
+	})
+}
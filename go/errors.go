@@ -18,13 +18,13 @@ func (e *Error) Error() string {
 
 // AuthenticationError indicates an invalid or missing API key.
 type AuthenticationError struct {
-	*Error
+	embeddedErr *Error
 }
 
 // NewAuthenticationError creates a new AuthenticationError.
 func NewAuthenticationError(message string) *AuthenticationError {
 	return &AuthenticationError{
-		Error: &Error{
+		embeddedErr: &Error{
 			Message:    message,
 			Code:       "AUTHENTICATION_ERROR",
 			StatusCode: 401,
@@ -32,16 +32,18 @@ func NewAuthenticationError(message string) *AuthenticationError {
 	}
 }
 
+func (e *AuthenticationError) Error() string { return e.embeddedErr.Error() }
+
 // NotFoundError indicates a resource was not found.
 type NotFoundError struct {
-	*Error
-	Resource string
+	embeddedErr *Error
+	Resource    string
 }
 
 // NewNotFoundError creates a new NotFoundError.
 func NewNotFoundError(resource string) *NotFoundError {
 	return &NotFoundError{
-		Error: &Error{
+		embeddedErr: &Error{
 			Message:    fmt.Sprintf("resource not found: %s", resource),
 			Code:       "NOT_FOUND",
 			StatusCode: 404,
@@ -50,16 +52,18 @@ func NewNotFoundError(resource string) *NotFoundError {
 	}
 }
 
+func (e *NotFoundError) Error() string { return e.embeddedErr.Error() }
+
 // RateLimitError indicates the rate limit was exceeded.
 type RateLimitError struct {
-	*Error
-	RetryAfter int
+	embeddedErr *Error
+	RetryAfter  int
 }
 
 // NewRateLimitError creates a new RateLimitError.
 func NewRateLimitError(retryAfter int) *RateLimitError {
 	return &RateLimitError{
-		Error: &Error{
+		embeddedErr: &Error{
 			Message:    "rate limit exceeded",
 			Code:       "RATE_LIMIT_EXCEEDED",
 			StatusCode: 429,
@@ -68,16 +72,18 @@ func NewRateLimitError(retryAfter int) *RateLimitError {
 	}
 }
 
+func (e *RateLimitError) Error() string { return e.embeddedErr.Error() }
+
 // ValidationError indicates invalid request data.
 type ValidationError struct {
-	*Error
-	Details string
+	embeddedErr *Error
+	Details     string
 }
 
 // NewValidationError creates a new ValidationError.
 func NewValidationError(details string) *ValidationError {
 	return &ValidationError{
-		Error: &Error{
+		embeddedErr: &Error{
 			Message:    fmt.Sprintf("validation error: %s", details),
 			Code:       "VALIDATION_ERROR",
 			StatusCode: 422,
@@ -86,16 +92,49 @@ func NewValidationError(details string) *ValidationError {
 	}
 }
 
+func (e *ValidationError) Error() string { return e.embeddedErr.Error() }
+
+// ChainBreakError indicates that a MemoryEntry sequence failed local hash
+// chain verification: either a recomputed hash didn't match the entry's
+// stored Hash, or an entry's PrevHash didn't match the previous entry's
+// Hash.
+type ChainBreakError struct {
+	embeddedErr  *Error
+	Index        int
+	Field        string
+	ExpectedHash string
+	ActualHash   string
+}
+
+// NewChainBreakError creates a new ChainBreakError naming the first broken
+// entry, its index, and the expected vs. actual values for the offending
+// field ("hash" or "prev_hash").
+func NewChainBreakError(index int, field, expected, actual string) *ChainBreakError {
+	return &ChainBreakError{
+		embeddedErr: &Error{
+			Message:    fmt.Sprintf("chain break at index %d: %s mismatch", index, field),
+			Code:       "CHAIN_BREAK",
+			StatusCode: 0,
+		},
+		Index:        index,
+		Field:        field,
+		ExpectedHash: expected,
+		ActualHash:   actual,
+	}
+}
+
+func (e *ChainBreakError) Error() string { return e.embeddedErr.Error() }
+
 // ConnectionError indicates a network or connection failure.
 type ConnectionError struct {
-	*Error
-	Cause error
+	embeddedErr *Error
+	Cause       error
 }
 
 // NewConnectionError creates a new ConnectionError.
 func NewConnectionError(message string, cause error) *ConnectionError {
 	return &ConnectionError{
-		Error: &Error{
+		embeddedErr: &Error{
 			Message: message,
 			Code:    "CONNECTION_ERROR",
 		},
@@ -103,6 +142,8 @@ func NewConnectionError(message string, cause error) *ConnectionError {
 	}
 }
 
+func (e *ConnectionError) Error() string { return e.embeddedErr.Error() }
+
 func (e *ConnectionError) Unwrap() error {
 	return e.Cause
 }
@@ -14,9 +14,10 @@ type MemoryAPI struct {
 	client *Client
 }
 
-// Log creates a new memory entry.
-func (m *MemoryAPI) Log(ctx context.Context, opts *LogMemoryOptions) (*MemoryEntry, error) {
-	resp, err := m.client.Post(ctx, "/memory", opts)
+// Log creates a new memory entry. Pass WithIdempotencyKey so a retried
+// request on a 5xx or timeout doesn't create a duplicate entry.
+func (m *MemoryAPI) Log(ctx context.Context, opts *LogMemoryOptions, reqOpts ...RequestOption) (*MemoryEntry, error) {
+	resp, err := m.client.Post(ctx, "/memory", opts, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -116,12 +117,13 @@ func (m *MemoryAPI) SyncState(ctx context.Context, agentID string, state map[str
 	return err
 }
 
-// Broadcast sends a broadcast message.
-func (m *MemoryAPI) Broadcast(ctx context.Context, msgType string, payload string) (string, error) {
+// Broadcast sends a broadcast message. Pass WithIdempotencyKey so a retried
+// request on a 5xx or timeout doesn't deliver the broadcast twice.
+func (m *MemoryAPI) Broadcast(ctx context.Context, msgType string, payload string, reqOpts ...RequestOption) (string, error) {
 	resp, err := m.client.Post(ctx, "/memory/broadcast", map[string]string{
 		"type":    msgType,
 		"payload": payload,
-	})
+	}, reqOpts...)
 	if err != nil {
 		return "", err
 	}
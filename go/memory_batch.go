@@ -0,0 +1,163 @@
+package blackroad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// BatchOptions controls how LogBatch executes.
+type BatchOptions struct {
+	// Concurrency bounds the number of concurrent Log calls used when
+	// falling back to client-side fan-out. Defaults to 5. Ignored when
+	// ChainLocally is set, since chained writes must happen in order.
+	Concurrency int
+
+	// ChainLocally computes each entry's PrevHash client-side, chaining it
+	// against the previously accepted entry's hash, so the batch remains
+	// verifiable even when the fallback path can't rely on the server
+	// assigning a consistent chain order. Only the client-side fallback
+	// honors it; entries sent to /memory/batch are passed as given.
+	ChainLocally bool
+}
+
+// BatchError records the failure of a single entry within a LogBatch call.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalJSON reconstructs a BatchError from the server's
+// {"index": <int>, "error": <string>} representation. encoding/json has no
+// way to unmarshal into the `error` interface directly, so the message is
+// decoded separately and wrapped in a plain error.
+func (e *BatchError) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Index int    `json:"index"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	e.Index = wire.Index
+	if wire.Error != "" {
+		e.Err = errors.New(wire.Error)
+	}
+	return nil
+}
+
+// BatchResult is the result of a LogBatch call. Entries preserves input
+// order for the entries that succeeded; Errors names the index of every
+// entry that failed.
+type BatchResult struct {
+	Entries []MemoryEntry
+	Errors  []BatchError
+}
+
+// LogBatch logs many memory entries at once. It POSTs to /memory/batch when
+// the server supports it, and otherwise falls back to Log calls on the
+// client: concurrent and bounded by opts.Concurrency normally, or
+// sequential with an explicit PrevHash chain when opts.ChainLocally is set.
+// This is meant for bulk-importing TIL entries or replaying event logs.
+func (m *MemoryAPI) LogBatch(ctx context.Context, entries []LogMemoryOptions, opts *BatchOptions) (*BatchResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+
+	result, err := m.logBatchServer(ctx, entries)
+	if err == nil {
+		return result, nil
+	}
+	if _, unsupported := err.(*NotFoundError); !unsupported {
+		return nil, err
+	}
+
+	if opts.ChainLocally {
+		return m.logBatchChained(ctx, entries), nil
+	}
+	return m.logBatchConcurrent(ctx, entries, opts.Concurrency), nil
+}
+
+func (m *MemoryAPI) logBatchServer(ctx context.Context, entries []LogMemoryOptions) (*BatchResult, error) {
+	resp, err := m.client.Post(ctx, "/memory/batch", map[string]interface{}{"entries": entries})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Entries []MemoryEntry `json:"entries"`
+		Errors  []BatchError  `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, NewConnectionError("failed to parse batch response", err)
+	}
+	return &BatchResult{Entries: result.Entries, Errors: result.Errors}, nil
+}
+
+// logBatchChained writes entries one at a time, pinning each one's PrevHash
+// to the previous entry's assigned Hash.
+func (m *MemoryAPI) logBatchChained(ctx context.Context, entries []LogMemoryOptions) *BatchResult {
+	out := &BatchResult{}
+	var prevHash string
+	for i, opts := range entries {
+		opts.PrevHash = prevHash
+		entry, err := m.Log(ctx, &opts)
+		if err != nil {
+			out.Errors = append(out.Errors, BatchError{Index: i, Err: err})
+			continue
+		}
+		out.Entries = append(out.Entries, *entry)
+		prevHash = entry.Hash
+	}
+	return out
+}
+
+// logBatchConcurrent fans out independent Log calls bounded by concurrency,
+// collecting per-item errors instead of aborting the whole batch.
+func (m *MemoryAPI) logBatchConcurrent(ctx context.Context, entries []LogMemoryOptions, concurrency int) *BatchResult {
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+
+	results := make([]MemoryEntry, len(entries))
+	errs := make([]error, len(entries))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			opts := entries[i]
+			entry, err := m.Log(ctx, &opts)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = *entry
+		}(i)
+	}
+	wg.Wait()
+
+	out := &BatchResult{}
+	for i := range entries {
+		if errs[i] != nil {
+			out.Errors = append(out.Errors, BatchError{Index: i, Err: errs[i]})
+			continue
+		}
+		out.Entries = append(out.Entries, results[i])
+	}
+	return out
+}
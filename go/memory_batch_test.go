@@ -0,0 +1,46 @@
+package blackroad
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestBatchErrorUnmarshalJSON exercises the /memory/batch response shape
+// logBatchServer decodes, including a non-empty errors array — the
+// partial-failure case the endpoint exists to report.
+func TestBatchErrorUnmarshalJSON(t *testing.T) {
+	const body = `{
+		"entries": [
+			{"hash":"abc123","timestamp":"2026-01-01T00:00:00Z","action":"create","entity":"task-1"}
+		],
+		"errors": [
+			{"index": 1, "error": "entity already exists"}
+		]
+	}`
+
+	var result struct {
+		Entries []MemoryEntry `json:"entries"`
+		Errors  []BatchError  `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("unmarshal /memory/batch response: %v", err)
+	}
+
+	if len(result.Entries) != 1 || result.Entries[0].Hash != "abc123" {
+		t.Fatalf("unexpected entries: %+v", result.Entries)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1", len(result.Errors))
+	}
+	be := result.Errors[0]
+	if be.Index != 1 {
+		t.Errorf("Index = %d, want 1", be.Index)
+	}
+	if be.Err == nil || be.Err.Error() != "entity already exists" {
+		t.Errorf("Err = %v, want %q", be.Err, "entity already exists")
+	}
+	if got := be.Error(); got != "entity already exists" {
+		t.Errorf("Error() = %q, want %q", got, "entity already exists")
+	}
+}
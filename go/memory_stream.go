@@ -0,0 +1,228 @@
+package blackroad
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errReadDeadlineExceeded is returned by connectOnce when the subscription's
+// read deadline elapses; the caller reconnects like any other transient
+// error.
+var errReadDeadlineExceeded = errors.New("blackroad: subscription read deadline exceeded")
+
+// MemoryEvent is a single push notification delivered over a Subscribe
+// stream: either a newly written MemoryEntry or a broadcast message.
+type MemoryEvent struct {
+	Type      string            `json:"type"`
+	Entry     *MemoryEntry      `json:"entry,omitempty"`
+	Broadcast *BroadcastMessage `json:"broadcast,omitempty"`
+	ID        string            `json:"-"`
+}
+
+// BroadcastMessage is the payload of a "broadcast" MemoryEvent.
+type BroadcastMessage struct {
+	Type    string `json:"type"`
+	Payload string `json:"payload"`
+}
+
+// SubscribeOptions filters the events delivered by Subscribe.
+type SubscribeOptions struct {
+	Action string
+	Entity string
+	Tags   []string
+
+	// LastEventID resumes the stream after the given event, as if the
+	// connection had never dropped.
+	LastEventID string
+}
+
+const (
+	subscribeBackoffBase = 500 * time.Millisecond
+	subscribeBackoffMax  = 30 * time.Second
+)
+
+// MemorySubscription is a live handle to a Subscribe stream.
+type MemorySubscription struct {
+	events chan MemoryEvent
+	errs   chan error
+
+	mu          sync.Mutex
+	lastEventID string
+
+	readDeadline *deadlineTimer
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Events returns the channel of delivered events. It is closed once the
+// subscription stops, after Close or ctx cancellation.
+func (s *MemorySubscription) Events() <-chan MemoryEvent { return s.events }
+
+// Errors returns transient connection errors encountered while streaming;
+// the subscription keeps reconnecting after each one.
+func (s *MemorySubscription) Errors() <-chan error { return s.errs }
+
+// LastEventID returns the ID of the most recently delivered event, which
+// can be passed back as SubscribeOptions.LastEventID to resume after a
+// disconnect.
+func (s *MemorySubscription) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastEventID
+}
+
+// Close stops the subscription and releases the underlying connection.
+func (s *MemorySubscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// SetReadDeadline pushes out or pulls in the point at which the current
+// connection is dropped and reconnected, without tearing down the
+// subscription itself. A zero time.Time clears the deadline. The deadline
+// is absolute: delivered events do not extend it, and once it has passed
+// it stays in effect for every reconnect until SetReadDeadline is called
+// again.
+func (s *MemorySubscription) SetReadDeadline(t time.Time) {
+	s.readDeadline.setDeadline(t)
+}
+
+func (s *MemorySubscription) setLastEventID(id string) {
+	if id == "" {
+		return
+	}
+	s.mu.Lock()
+	s.lastEventID = id
+	s.mu.Unlock()
+}
+
+// Subscribe opens a long-lived SSE connection to /memory/stream and pushes
+// MemoryEntry writes and broadcast messages to the returned subscription as
+// they happen, so callers don't have to poll Recent/Query. The connection
+// auto-reconnects with exponential backoff on transient errors; cancelling
+// ctx or calling MemorySubscription.Close tears it down.
+func (m *MemoryAPI) Subscribe(ctx context.Context, opts *SubscribeOptions, reqOpts ...RequestOption) (*MemorySubscription, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	sub := &MemorySubscription{
+		events:       make(chan MemoryEvent),
+		errs:         make(chan error, 1),
+		readDeadline: newDeadlineTimer(),
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+	if opts != nil {
+		sub.lastEventID = opts.LastEventID
+	}
+
+	go sub.run(streamCtx, m.client, opts, reqOpts...)
+	return sub, nil
+}
+
+func (s *MemorySubscription) run(ctx context.Context, client *Client, opts *SubscribeOptions, reqOpts ...RequestOption) {
+	defer close(s.done)
+	defer close(s.events)
+
+	backoff := subscribeBackoffBase
+	for {
+		connectedAt := time.Now()
+		err := s.connectOnce(ctx, client, opts, reqOpts...)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case s.errs <- err:
+			default:
+			}
+		}
+
+		// A connection that stayed up for a while was healthy; don't keep
+		// penalizing future reconnects for transient errors seen long ago.
+		if time.Since(connectedAt) >= subscribeBackoffBase {
+			backoff = subscribeBackoffBase
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > subscribeBackoffMax {
+			backoff = subscribeBackoffMax
+		}
+	}
+}
+
+func (s *MemorySubscription) connectOnce(ctx context.Context, client *Client, opts *SubscribeOptions, reqOpts ...RequestOption) error {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Action != "" {
+			params.Set("action", opts.Action)
+		}
+		if opts.Entity != "" {
+			params.Set("entity", opts.Entity)
+		}
+		if len(opts.Tags) > 0 {
+			params.Set("tags", strings.Join(opts.Tags, ","))
+		}
+	}
+
+	resp, err := client.stream(ctx, "/memory/stream", params, s.LastEventID(), reqOpts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	type scanResult struct {
+		evt *sseEvent
+		err error
+	}
+	results := make(chan scanResult, 2)
+	go func() {
+		evt, ok := scanSSE(scanner)
+		for ok {
+			results <- scanResult{evt: evt}
+			evt, ok = scanSSE(scanner)
+		}
+		results <- scanResult{err: scanner.Err()}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.readDeadline.channel():
+			return errReadDeadlineExceeded
+		case r := <-results:
+			if r.evt == nil {
+				return r.err
+			}
+			if r.evt.ID != "" {
+				s.setLastEventID(r.evt.ID)
+			}
+
+			var mevt MemoryEvent
+			if err := json.Unmarshal([]byte(r.evt.Data), &mevt); err != nil {
+				continue
+			}
+			mevt.ID = r.evt.ID
+
+			select {
+			case s.events <- mevt:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package blackroad
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RoundTripFunc performs a single HTTP round trip attempt.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to add cross-cutting behavior (deadline
+// propagation, rate limiting, logging) around every request attempt a
+// Client makes.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+func chainMiddleware(base RoundTripFunc, mws []Middleware) RoundTripFunc {
+	for i := len(mws) - 1; i >= 0; i-- {
+		base = mws[i](base)
+	}
+	return base
+}
+
+// RateLimiter is satisfied by *rate.Limiter from golang.org/x/time/rate,
+// letting callers plug in real rate limiting without this module taking the
+// dependency itself.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithDeadlineHeader sets an X-Client-Deadline header naming the absolute
+// time by which the caller needs a response, in addition to (not instead
+// of) any context deadline or WithRequestTimeout. Server-side, this lets a
+// long-running handler abandon work the client has already given up on.
+func WithDeadlineHeader(d time.Duration) RequestOption {
+	return func(ro *requestOptions) { ro.deadlineFor = d }
+}
+
+// WithRateLimit blocks the request on limiter.Wait(ctx) before it's sent,
+// so a client hitting a shared quota doesn't need to hand-roll throttling
+// around every call site.
+func WithRateLimit(limiter RateLimiter) RequestOption {
+	return func(ro *requestOptions) { ro.rateLimit = limiter }
+}
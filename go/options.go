@@ -0,0 +1,83 @@
+package blackroad
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request should be retried and how
+// long to wait before the next attempt. start is the time the request's
+// first attempt began, so a policy with an overall time budget (like
+// ExponentialBackoff.MaxElapsed) can measure elapsed time per request
+// instead of keeping that state on the policy itself — the same policy
+// value is normally shared across concurrent requests on one Client.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, start time.Time, resp *http.Response, err error) (retry bool, delay time.Duration)
+}
+
+// RequestOption configures a single API request, overriding the client's
+// defaults for that call only.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotencyKey string
+	headers        map[string]string
+	timeout        time.Duration
+	retryPolicy    RetryPolicy
+	deadlineFor    time.Duration
+	rateLimit      RateLimiter
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	ro := &requestOptions{}
+	for _, opt := range opts {
+		opt(ro)
+	}
+	return ro
+}
+
+// WithIdempotencyKey attaches an Idempotency-Key header to a POST or PUT
+// request so retries of the same logical operation don't create duplicate
+// resources server-side (e.g. duplicate memory entries or agent
+// registrations). GET and DELETE requests never send the header.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(ro *requestOptions) { ro.idempotencyKey = key }
+}
+
+// WithHeader sets an additional header on the request.
+func WithHeader(key, value string) RequestOption {
+	return func(ro *requestOptions) {
+		if ro.headers == nil {
+			ro.headers = map[string]string{}
+		}
+		ro.headers[key] = value
+	}
+}
+
+// WithRequestTimeout bounds a single request, across all of its retry
+// attempts, with a context deadline. Each attempt is still limited by the
+// HTTP client's own Timeout, so this can shorten the client's default
+// timeout but not extend it.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(ro *requestOptions) { ro.timeout = d }
+}
+
+// WithRetryPolicy overrides the client's default retry policy for a single
+// request.
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
+	return func(ro *requestOptions) { ro.retryPolicy = policy }
+}
+
+// generateIdempotencyKey returns a random UUIDv4 for requests that need one
+// but weren't given an explicit WithIdempotencyKey.
+func generateIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("blackroad-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
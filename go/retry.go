@@ -0,0 +1,148 @@
+package blackroad
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryBase = 500 * time.Millisecond
+	defaultRetryMax  = 30 * time.Second
+)
+
+// ExponentialBackoff is the client's default RetryPolicy: binary
+// exponential backoff with full jitter, bounded by Max and an overall
+// MaxElapsed budget.
+type ExponentialBackoff struct {
+	// Base is the delay before the first retry. Defaults to 500ms.
+	Base time.Duration
+	// Max caps the computed backoff delay. Defaults to 30s. A delay taken
+	// from a Retry-After header is used as given, even when it exceeds Max.
+	Max time.Duration
+	// Jitter randomizes the computed delay uniformly in [0, delay].
+	Jitter bool
+	// RetryOn lists additional status codes to retry, beyond the default
+	// 429/502/503/504.
+	RetryOn []int
+	// MaxElapsed bounds the total time spent retrying a single logical
+	// request. Zero means no limit.
+	MaxElapsed time.Duration
+}
+
+// DefaultRetryPolicy returns the client's out-of-the-box retry policy.
+func DefaultRetryPolicy() *ExponentialBackoff {
+	return &ExponentialBackoff{Base: defaultRetryBase, Max: defaultRetryMax, Jitter: true}
+}
+
+// ShouldRetry implements RetryPolicy. It retries network errors and
+// 429/502/503/504 responses (plus anything listed in RetryOn), honoring
+// Retry-After on 429 and 503, and gives up once MaxElapsed has passed.
+func (b *ExponentialBackoff) ShouldRetry(attempt int, start time.Time, resp *http.Response, err error) (bool, time.Duration) {
+	if b.MaxElapsed > 0 && time.Since(start) > b.MaxElapsed {
+		return false, 0
+	}
+
+	if err != nil {
+		return true, b.delay(attempt)
+	}
+	if resp == nil || !b.retryableStatus(resp.StatusCode) {
+		return false, 0
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if d, ok := retryAfterDelay(resp); ok {
+			return true, d
+		}
+	}
+	return true, b.delay(attempt)
+}
+
+func (b *ExponentialBackoff) retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	for _, c := range b.RetryOn {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *ExponentialBackoff) delay(attempt int) time.Duration {
+	base := b.Base
+	if base <= 0 {
+		base = defaultRetryBase
+	}
+	max := b.Max
+	if max <= 0 {
+		max = defaultRetryMax
+	}
+
+	d := base << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+	if b.Jitter {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+	return d
+}
+
+// NoRetry disables retries entirely; useful in tests that want
+// deterministic, single-attempt behavior.
+type NoRetry struct{}
+
+// ShouldRetry implements RetryPolicy.
+func (NoRetry) ShouldRetry(attempt int, start time.Time, resp *http.Response, err error) (bool, time.Duration) {
+	return false, 0
+}
+
+// retryAfterDelay parses a Retry-After header as either delay-seconds or an
+// HTTP-date, per RFC 7231.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	ra := resp.Header.Get("Retry-After")
+	if ra == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(ra); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(ra); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// isIdempotentMethod reports whether method is safe to retry without an
+// explicit idempotency key.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleepCtx blocks for d or until ctx is done, whichever comes first. It
+// reports whether the sleep completed normally.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
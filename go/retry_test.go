@@ -0,0 +1,146 @@
+package blackroad
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func respWithStatus(code int, headers map[string]string) *http.Response {
+	h := http.Header{}
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return &http.Response{StatusCode: code, Header: h}
+}
+
+func TestExponentialBackoffShouldRetry(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name      string
+		policy    *ExponentialBackoff
+		resp      *http.Response
+		err       error
+		start     time.Time
+		wantRetry bool
+	}{
+		{
+			name:      "network error retries",
+			policy:    DefaultRetryPolicy(),
+			err:       errors.New("connection reset"),
+			start:     start,
+			wantRetry: true,
+		},
+		{
+			name:      "success is not retried",
+			policy:    DefaultRetryPolicy(),
+			resp:      respWithStatus(http.StatusOK, nil),
+			start:     start,
+			wantRetry: false,
+		},
+		{
+			name:      "4xx other than 429 is not retried",
+			policy:    DefaultRetryPolicy(),
+			resp:      respWithStatus(http.StatusUnprocessableEntity, nil),
+			start:     start,
+			wantRetry: false,
+		},
+		{
+			name:      "429 is retried",
+			policy:    DefaultRetryPolicy(),
+			resp:      respWithStatus(http.StatusTooManyRequests, nil),
+			start:     start,
+			wantRetry: true,
+		},
+		{
+			name:      "502 is retried",
+			policy:    DefaultRetryPolicy(),
+			resp:      respWithStatus(http.StatusBadGateway, nil),
+			start:     start,
+			wantRetry: true,
+		},
+		{
+			name:      "RetryOn extends the retryable set",
+			policy:    &ExponentialBackoff{Base: time.Millisecond, Max: time.Second, RetryOn: []int{http.StatusConflict}},
+			resp:      respWithStatus(http.StatusConflict, nil),
+			start:     start,
+			wantRetry: true,
+		},
+		{
+			name:      "MaxElapsed budget exhausted stops retrying",
+			policy:    &ExponentialBackoff{Base: time.Millisecond, Max: time.Second, MaxElapsed: time.Millisecond},
+			resp:      respWithStatus(http.StatusServiceUnavailable, nil),
+			start:     time.Now().Add(-time.Hour),
+			wantRetry: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			retry, _ := tc.policy.ShouldRetry(0, tc.start, tc.resp, tc.err)
+			if retry != tc.wantRetry {
+				t.Errorf("ShouldRetry() = %v, want %v", retry, tc.wantRetry)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffHonorsRetryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	resp := respWithStatus(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"})
+
+	retry, delay := policy.ShouldRetry(0, time.Now(), resp, nil)
+	if !retry {
+		t.Fatal("expected retry on 429")
+	}
+	if delay != 5*time.Second {
+		t.Errorf("delay = %v, want 5s", delay)
+	}
+}
+
+func TestExponentialBackoffDelayBounds(t *testing.T) {
+	policy := &ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		if d := policy.delay(attempt); d > time.Second {
+			t.Errorf("delay(%d) = %v, want <= Max (%v)", attempt, d, time.Second)
+		}
+	}
+}
+
+func TestNoRetryNeverRetries(t *testing.T) {
+	retry, delay := (NoRetry{}).ShouldRetry(0, time.Now(), respWithStatus(http.StatusServiceUnavailable, nil), errors.New("boom"))
+	if retry || delay != 0 {
+		t.Errorf("NoRetry.ShouldRetry() = (%v, %v), want (false, 0)", retry, delay)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	if _, ok := retryAfterDelay(respWithStatus(http.StatusServiceUnavailable, nil)); ok {
+		t.Error("expected no delay when Retry-After is absent")
+	}
+
+	d, ok := retryAfterDelay(respWithStatus(http.StatusServiceUnavailable, map[string]string{"Retry-After": "2"}))
+	if !ok || d != 2*time.Second {
+		t.Errorf("retryAfterDelay(seconds) = (%v, %v), want (2s, true)", d, ok)
+	}
+
+	future := time.Now().Add(time.Minute)
+	d, ok = retryAfterDelay(respWithStatus(http.StatusServiceUnavailable, map[string]string{"Retry-After": future.UTC().Format(http.TimeFormat)}))
+	if !ok || d <= 0 || d > time.Minute {
+		t.Errorf("retryAfterDelay(HTTP-date) = (%v, %v), want a positive delay <= 1m", d, ok)
+	}
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	idempotent := []string{http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions}
+	for _, m := range idempotent {
+		if !isIdempotentMethod(m) {
+			t.Errorf("isIdempotentMethod(%q) = false, want true", m)
+		}
+	}
+	if isIdempotentMethod(http.MethodPost) {
+		t.Error("isIdempotentMethod(POST) = true, want false")
+	}
+}
@@ -0,0 +1,56 @@
+package blackroad
+
+import (
+	"bufio"
+	"strings"
+)
+
+// sseEvent is a single parsed Server-Sent Event frame.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// scanSSE reads one event from scanner following the text/event-stream
+// framing rules: fields are newline-separated, repeated "data:" lines are
+// joined with "\n", and a blank line terminates the event. It returns
+// ok=false once the underlying reader is exhausted.
+func scanSSE(scanner *bufio.Scanner) (*sseEvent, bool) {
+	var evt sseEvent
+	var data []string
+	sawAny := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if sawAny {
+				evt.Data = strings.Join(data, "\n")
+				return &evt, true
+			}
+			continue
+		}
+		sawAny = true
+
+		field, value := line, ""
+		if idx := strings.Index(line, ":"); idx >= 0 {
+			field = line[:idx]
+			value = strings.TrimPrefix(line[idx+1:], " ")
+		}
+
+		switch field {
+		case "id":
+			evt.ID = value
+		case "event":
+			evt.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if sawAny {
+		evt.Data = strings.Join(data, "\n")
+		return &evt, true
+	}
+	return nil, false
+}
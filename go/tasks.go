@@ -13,13 +13,14 @@ type TaskAPI struct {
 	client *Client
 }
 
-// Dispatch creates a new task.
-func (t *TaskAPI) Dispatch(ctx context.Context, opts *DispatchTaskOptions) (*Task, error) {
+// Dispatch creates a new task. Pass WithIdempotencyKey so a retried request
+// on a 5xx or timeout doesn't dispatch the task twice.
+func (t *TaskAPI) Dispatch(ctx context.Context, opts *DispatchTaskOptions, reqOpts ...RequestOption) (*Task, error) {
 	if opts.Priority == "" {
 		opts.Priority = "medium"
 	}
 
-	resp, err := t.client.Post(ctx, "/tasks", opts)
+	resp, err := t.client.Post(ctx, "/tasks", opts, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,8 +33,8 @@ func (t *TaskAPI) Dispatch(ctx context.Context, opts *DispatchTaskOptions) (*Tas
 }
 
 // Get returns a specific task by ID.
-func (t *TaskAPI) Get(ctx context.Context, taskID string) (*Task, error) {
-	resp, err := t.client.Get(ctx, fmt.Sprintf("/tasks/%s", taskID), nil)
+func (t *TaskAPI) Get(ctx context.Context, taskID string, reqOpts ...RequestOption) (*Task, error) {
+	resp, err := t.client.Get(ctx, fmt.Sprintf("/tasks/%s", taskID), nil, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +47,7 @@ func (t *TaskAPI) Get(ctx context.Context, taskID string) (*Task, error) {
 }
 
 // List returns tasks with optional filters.
-func (t *TaskAPI) List(ctx context.Context, opts *TaskListOptions) ([]Task, error) {
+func (t *TaskAPI) List(ctx context.Context, opts *TaskListOptions, reqOpts ...RequestOption) ([]Task, error) {
 	params := url.Values{}
 	if opts != nil {
 		if opts.Status != "" {
@@ -66,7 +67,7 @@ func (t *TaskAPI) List(ctx context.Context, opts *TaskListOptions) ([]Task, erro
 		}
 	}
 
-	resp, err := t.client.Get(ctx, "/tasks", params)
+	resp, err := t.client.Get(ctx, "/tasks", params, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +81,9 @@ func (t *TaskAPI) List(ctx context.Context, opts *TaskListOptions) ([]Task, erro
 	return result.Tasks, nil
 }
 
-// Complete marks a task as completed.
-func (t *TaskAPI) Complete(ctx context.Context, taskID string, result string) (*Task, error) {
+// Complete marks a task as completed. Pass WithIdempotencyKey so a retried
+// request on a 5xx or timeout doesn't reapply the status change twice.
+func (t *TaskAPI) Complete(ctx context.Context, taskID string, result string, reqOpts ...RequestOption) (*Task, error) {
 	body := map[string]interface{}{
 		"status": "completed",
 	}
@@ -89,7 +91,7 @@ func (t *TaskAPI) Complete(ctx context.Context, taskID string, result string) (*
 		body["result"] = result
 	}
 
-	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body)
+	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +104,7 @@ func (t *TaskAPI) Complete(ctx context.Context, taskID string, result string) (*
 }
 
 // Fail marks a task as failed.
-func (t *TaskAPI) Fail(ctx context.Context, taskID string, reason string) (*Task, error) {
+func (t *TaskAPI) Fail(ctx context.Context, taskID string, reason string, reqOpts ...RequestOption) (*Task, error) {
 	body := map[string]interface{}{
 		"status": "failed",
 	}
@@ -110,7 +112,7 @@ func (t *TaskAPI) Fail(ctx context.Context, taskID string, reason string) (*Task
 		body["result"] = reason
 	}
 
-	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body)
+	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,13 +125,13 @@ func (t *TaskAPI) Fail(ctx context.Context, taskID string, reason string) (*Task
 }
 
 // Assign assigns a task to an agent.
-func (t *TaskAPI) Assign(ctx context.Context, taskID string, agentID string) (*Task, error) {
+func (t *TaskAPI) Assign(ctx context.Context, taskID string, agentID string, reqOpts ...RequestOption) (*Task, error) {
 	body := map[string]interface{}{
 		"assigned_agent": agentID,
 		"status":         "assigned",
 	}
 
-	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body)
+	resp, err := t.client.Put(ctx, fmt.Sprintf("/tasks/%s", taskID), body, reqOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -142,8 +144,8 @@ func (t *TaskAPI) Assign(ctx context.Context, taskID string, agentID string) (*T
 }
 
 // Cancel cancels a task.
-func (t *TaskAPI) Cancel(ctx context.Context, taskID string) error {
-	_, err := t.client.Delete(ctx, fmt.Sprintf("/tasks/%s", taskID))
+func (t *TaskAPI) Cancel(ctx context.Context, taskID string, reqOpts ...RequestOption) error {
+	_, err := t.client.Delete(ctx, fmt.Sprintf("/tasks/%s", taskID), reqOpts...)
 	return err
 }
 
@@ -0,0 +1,251 @@
+package blackroad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const bulkFallbackConcurrency = 10
+
+// BulkError aggregates the per-item errors from a bulk operation. It
+// satisfies errors.Is/errors.As via Unwrap, matching against any of its
+// constituent errors.
+type BulkError struct {
+	Errors []BatchError
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, be := range e.Errors {
+		msgs[i] = fmt.Sprintf("[%d] %s", be.Index, be.Err)
+	}
+	return fmt.Sprintf("blackroad: %d bulk operations failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes every constituent error so errors.Is/errors.As can match
+// against any one of them.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, be := range e.Errors {
+		errs[i] = be.Err
+	}
+	return errs
+}
+
+func bulkErrorOrNil(errs []BatchError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &BulkError{Errors: errs}
+}
+
+// BulkDispatch dispatches many tasks at once, using /tasks/bulk when the
+// server supports it and otherwise bounded-concurrency parallel Dispatch
+// calls. It returns tasks and per-item errors in input order. If
+// /tasks/bulk fails with anything other than a 404, it returns no tasks and
+// that single error.
+func (t *TaskAPI) BulkDispatch(ctx context.Context, opts []*DispatchTaskOptions) ([]Task, []error) {
+	if tasks, err := t.bulkDispatchServer(ctx, opts); err == nil {
+		return tasks, nil
+	} else if _, unsupported := err.(*NotFoundError); !unsupported {
+		return nil, []error{err}
+	}
+
+	results := make([]Task, len(opts))
+	errs := make([]error, len(opts))
+	runBounded(len(opts), bulkFallbackConcurrency, func(i int) {
+		task, err := t.Dispatch(ctx, opts[i])
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		results[i] = *task
+	})
+	return results, errs
+}
+
+func (t *TaskAPI) bulkDispatchServer(ctx context.Context, opts []*DispatchTaskOptions) ([]Task, error) {
+	resp, err := t.client.Post(ctx, "/tasks/bulk", map[string]interface{}{"tasks": opts})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Tasks []Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, NewConnectionError("failed to parse bulk dispatch response", err)
+	}
+	return result.Tasks, nil
+}
+
+// BulkComplete marks many tasks completed at once, using /tasks/bulk when
+// available and otherwise bounded-concurrency parallel Complete calls.
+func (t *TaskAPI) BulkComplete(ctx context.Context, ids []string, result string) error {
+	return t.bulkStatusChange(ctx, ids, "completed", func(ctx context.Context, id string) error {
+		_, err := t.Complete(ctx, id, result)
+		return err
+	})
+}
+
+// BulkFail marks many tasks failed at once.
+func (t *TaskAPI) BulkFail(ctx context.Context, ids []string, reason string) error {
+	return t.bulkStatusChange(ctx, ids, "failed", func(ctx context.Context, id string) error {
+		_, err := t.Fail(ctx, id, reason)
+		return err
+	})
+}
+
+// BulkCancel cancels many tasks at once.
+func (t *TaskAPI) BulkCancel(ctx context.Context, ids []string) error {
+	return t.bulkStatusChange(ctx, ids, "cancelled", func(ctx context.Context, id string) error {
+		return t.Cancel(ctx, id)
+	})
+}
+
+func (t *TaskAPI) bulkStatusChange(ctx context.Context, ids []string, status string, fallback func(ctx context.Context, id string) error) error {
+	_, err := t.client.Post(ctx, "/tasks/bulk", map[string]interface{}{
+		"ids":    ids,
+		"status": status,
+	})
+	if err == nil {
+		return nil
+	}
+	if _, unsupported := err.(*NotFoundError); !unsupported {
+		return err
+	}
+
+	errs := make([]error, len(ids))
+	runBounded(len(ids), bulkFallbackConcurrency, func(i int) {
+		errs[i] = fallback(ctx, ids[i])
+	})
+
+	var batchErrs []BatchError
+	for i, err := range errs {
+		if err != nil {
+			batchErrs = append(batchErrs, BatchError{Index: i, Err: err})
+		}
+	}
+	return bulkErrorOrNil(batchErrs)
+}
+
+// CancelWhere cancels every task matching opts, paging through List until
+// exhausted. It's meant for division-wide cleanups that would otherwise
+// require hand-rolling paging plus a goroutine pool.
+func (t *TaskAPI) CancelWhere(ctx context.Context, opts TaskListOptions) error {
+	var ids []string
+	iter := t.Iter(ctx, &opts)
+	for iter.Next() {
+		ids = append(ids, iter.Task().ID)
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return t.BulkCancel(ctx, ids)
+}
+
+// runBounded calls fn(i) for i in [0,n) with at most concurrency calls
+// in flight at once, and waits for all of them to finish.
+func runBounded(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = n
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// TaskIterator pages transparently through List via Offset/Limit.
+type TaskIterator struct {
+	ctx     context.Context
+	tasks   *TaskAPI
+	opts    TaskListOptions
+	page    []Task
+	idx     int
+	offset  int
+	limit   int
+	done    bool
+	err     error
+	current *Task
+}
+
+// Iter returns a TaskIterator over tasks matching opts, paging
+// transparently as the caller advances it with Next.
+func (t *TaskAPI) Iter(ctx context.Context, opts *TaskListOptions) *TaskIterator {
+	it := &TaskIterator{ctx: ctx, tasks: t, limit: 100}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Limit > 0 {
+		it.limit = it.opts.Limit
+	}
+	it.offset = it.opts.Offset
+	return it
+}
+
+// Next advances the iterator, fetching the next page from the server as
+// needed. It returns false once every matching task has been visited or an
+// error occurred; check Err to distinguish the two.
+func (it *TaskIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	for it.idx >= len(it.page) {
+		if it.page != nil && len(it.page) < it.limit {
+			it.done = true
+			return false
+		}
+
+		pageOpts := it.opts
+		pageOpts.Limit = it.limit
+		pageOpts.Offset = it.offset
+
+		page, err := it.tasks.List(it.ctx, &pageOpts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.idx = 0
+		it.offset += len(page)
+
+		if len(page) == 0 {
+			it.done = true
+			return false
+		}
+	}
+
+	it.current = &it.page[it.idx]
+	it.idx++
+	return true
+}
+
+// Task returns the task Next just advanced to.
+func (it *TaskIterator) Task() *Task { return it.current }
+
+// Err returns the first error encountered while paging, if any.
+func (it *TaskIterator) Err() error { return it.err }
+
+// ListAll materializes every task matching opts by paging through Iter.
+func (t *TaskAPI) ListAll(ctx context.Context, opts *TaskListOptions) ([]Task, error) {
+	var all []Task
+	iter := t.Iter(ctx, opts)
+	for iter.Next() {
+		all = append(all, *iter.Task())
+	}
+	return all, iter.Err()
+}
@@ -0,0 +1,160 @@
+package blackroad
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LogLine is a single line of task output.
+type LogLine struct {
+	Stream    string    `json:"stream"` // "stdout" or "stderr"
+	Line      string    `json:"line"`
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// LogsOptions controls Logs and StreamLogs.
+type LogsOptions struct {
+	// Follow keeps the connection open and streams new lines as they're
+	// written, like `tail -f`.
+	Follow bool
+	// Tail limits the initial read to the last N lines. Zero means all
+	// available lines.
+	Tail int
+	// Since restricts output to lines written at or after this time.
+	Since time.Time
+	// Stream selects "stdout", "stderr", or "all" (the default).
+	Stream string
+	// AfterOffset resumes the stream strictly after this line offset,
+	// taking precedence over Since when both are set. StreamLogs sets this
+	// automatically on reconnect so no lines are skipped or repeated.
+	AfterOffset int64
+}
+
+func (o *LogsOptions) params() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if o.Follow {
+		params.Set("follow", "true")
+	}
+	if o.Tail > 0 {
+		params.Set("tail", strconv.Itoa(o.Tail))
+	}
+	if o.AfterOffset > 0 {
+		params.Set("after_offset", strconv.FormatInt(o.AfterOffset, 10))
+	} else if !o.Since.IsZero() {
+		params.Set("since", o.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if o.Stream != "" {
+		params.Set("stream", o.Stream)
+	}
+	return params
+}
+
+// Logs returns the raw log stream for a task as an io.ReadCloser. With
+// opts.Follow set, the server keeps the connection open (chunked transfer)
+// and new output keeps arriving until the task ends or the caller closes
+// the returned reader. The caller must close it.
+func (t *TaskAPI) Logs(ctx context.Context, taskID string, opts *LogsOptions, reqOpts ...RequestOption) (io.ReadCloser, error) {
+	resp, err := t.client.stream(ctx, fmt.Sprintf("/tasks/%s/logs", taskID), opts.params(), "", reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// StreamLogs tails a task's logs as typed LogLine events instead of raw
+// bytes, auto-reconnecting on transient errors and resuming from the last
+// delivered offset so no lines are lost across reconnects. It mirrors the
+// `nomad logs -f` / task-hub tailing pattern and is a natural companion to
+// Complete/Fail for callers who want to watch a task end-to-end. Reconnects
+// happen only with opts.Follow set; without it, StreamLogs reads the
+// available output once and stops, even after an error.
+func (t *TaskAPI) StreamLogs(ctx context.Context, taskID string, opts *LogsOptions, reqOpts ...RequestOption) (<-chan LogLine, <-chan error) {
+	lines := make(chan LogLine)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(lines)
+
+		if opts == nil {
+			opts = &LogsOptions{}
+		}
+		local := *opts
+		backoff := subscribeBackoffBase
+
+		for {
+			connectedAt := time.Now()
+			offset, err := streamTaskLogsOnce(ctx, t.client, taskID, &local, lines, reqOpts...)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			}
+			if !local.Follow {
+				return
+			}
+			if offset > 0 {
+				local.AfterOffset = offset
+			}
+
+			// A connection that stayed up for a while was healthy; don't
+			// keep penalizing future reconnects for transient errors seen
+			// long ago.
+			if time.Since(connectedAt) >= subscribeBackoffBase {
+				backoff = subscribeBackoffBase
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > subscribeBackoffMax {
+				backoff = subscribeBackoffMax
+			}
+		}
+	}()
+
+	return lines, errs
+}
+
+func streamTaskLogsOnce(ctx context.Context, client *Client, taskID string, opts *LogsOptions, lines chan<- LogLine, reqOpts ...RequestOption) (int64, error) {
+	resp, err := client.stream(ctx, fmt.Sprintf("/tasks/%s/logs", taskID), opts.params(), "", reqOpts...)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lastOffset int64
+	for scanner.Scan() {
+		var line LogLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		lastOffset = line.Offset
+
+		select {
+		case lines <- line:
+		case <-ctx.Done():
+			return lastOffset, nil
+		}
+	}
+	return lastOffset, scanner.Err()
+}
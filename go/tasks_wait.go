@@ -0,0 +1,189 @@
+package blackroad
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var defaultTerminalStates = []string{"completed", "failed", "cancelled"}
+
+// WaitOptions controls Wait.
+type WaitOptions struct {
+	// Timeout bounds how long Wait blocks. Zero means no timeout beyond ctx.
+	Timeout time.Duration
+	// PollInterval is the first delay of the polling fallback when the
+	// server doesn't support long-polling; the delay doubles after each
+	// poll, up to 30 seconds. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// TerminalStates lists the statuses that end the wait. Defaults to
+	// completed, failed, cancelled.
+	TerminalStates []string
+}
+
+func (o *WaitOptions) terminalStates() []string {
+	if o == nil || len(o.TerminalStates) == 0 {
+		return defaultTerminalStates
+	}
+	return o.TerminalStates
+}
+
+func isTerminal(status string, terminal []string) bool {
+	for _, s := range terminal {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait blocks until taskID reaches one of opts.TerminalStates (or ctx is
+// cancelled / opts.Timeout elapses). It prefers the server's long-poll
+// support (?wait_for=<status>, blocking until the task changes state or the
+// server's own poll timeout), falling back to exponential-backoff polling
+// of Get when the server doesn't block.
+func (t *TaskAPI) Wait(ctx context.Context, taskID string, opts *WaitOptions) (*Task, error) {
+	if opts != nil && opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	terminal := opts.terminalStates()
+	pollInterval := 2 * time.Second
+	if opts != nil && opts.PollInterval > 0 {
+		pollInterval = opts.PollInterval
+	}
+
+	delay := pollInterval
+	for {
+		task, err := t.getWithWaitFor(ctx, taskID, terminal)
+		if err != nil {
+			return nil, err
+		}
+		if isTerminal(task.Status, terminal) {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay < subscribeBackoffMax {
+			delay *= 2
+			if delay > subscribeBackoffMax {
+				delay = subscribeBackoffMax
+			}
+		}
+	}
+}
+
+// getWithWaitFor issues a GET with a wait_for query param so a
+// long-poll-capable server can block server-side until the task's status
+// changes, instead of the client busy-polling. Servers that ignore the
+// param simply return immediately, and the caller's outer loop falls back
+// to interval polling.
+func (t *TaskAPI) getWithWaitFor(ctx context.Context, taskID string, terminal []string) (*Task, error) {
+	params := url.Values{}
+	for _, s := range terminal {
+		params.Add("wait_for", s)
+	}
+
+	resp, err := t.client.Get(ctx, "/tasks/"+taskID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(resp, &task); err != nil {
+		return nil, NewConnectionError("failed to parse task response", err)
+	}
+	return &task, nil
+}
+
+// CollectOptions controls Collect.
+type CollectOptions struct {
+	// MaxConcurrent bounds how many Wait calls run at once. Defaults to 10.
+	MaxConcurrent int
+	// WaitOptions is applied to every per-task Wait call.
+	WaitOptions *WaitOptions
+}
+
+// TaskResult is a single task's terminal outcome, delivered by Collect.
+type TaskResult struct {
+	TaskID string
+	Task   *Task
+	Err    error
+}
+
+// Collect waits for many tasks to reach a terminal state concurrently,
+// streaming each TaskResult as soon as that task finishes rather than
+// waiting for the whole set. Concurrency is bounded by
+// opts.MaxConcurrent, and cancelling ctx stops outstanding waits and closes
+// the returned channel.
+func (t *TaskAPI) Collect(ctx context.Context, taskIDs []string, opts *CollectOptions) (<-chan TaskResult, error) {
+	if opts == nil {
+		opts = &CollectOptions{}
+	}
+	maxConcurrent := opts.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = 10
+	}
+
+	results := make(chan TaskResult)
+
+	go func() {
+		defer close(results)
+
+		sem := make(chan struct{}, maxConcurrent)
+		out := make(chan TaskResult)
+
+		var wg sync.WaitGroup
+		wg.Add(len(taskIDs))
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		for _, id := range taskIDs {
+			id := id
+			go func() {
+				defer wg.Done()
+
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				defer func() { <-sem }()
+
+				task, err := t.Wait(ctx, id, opts.WaitOptions)
+				select {
+				case out <- TaskResult{TaskID: id, Task: task, Err: err}:
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		for {
+			select {
+			case r, ok := <-out:
+				if !ok {
+					return
+				}
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -112,6 +112,11 @@ type LogMemoryOptions struct {
 	Details  string                 `json:"details,omitempty"`
 	Tags     []string               `json:"tags,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// PrevHash, if set, pins this entry to a specific predecessor in the
+	// hash chain instead of letting the server chain it onto whatever was
+	// written last. BatchOptions.ChainLocally sets this automatically.
+	PrevHash string `json:"prev_hash,omitempty"`
 }
 
 // MemoryQueryOptions contains options for querying memory.